@@ -0,0 +1,74 @@
+// Command gocache-gen emits a type-specialized copy of goCache's cache for
+// a single concrete value type: Item, cache, Cache, Set, Get, Add, Replace,
+// Delete, OnEvicted, the janitor, and (only when the value type is
+// numeric) Increment, all with interface{} replaced by that type. This
+// gives callers a zero-allocation, zero-type-assertion cache without
+// waiting on generics adoption in their own toolchain.
+//
+// Drop a directive like the following into a package that wants a
+// specialized cache:
+//
+//	//go:generate go run charles/goCache/cmd/gocache-gen -type=Session -package=session -output=session_cache.go
+package main
+
+import (
+	_ "embed"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"strings"
+)
+
+//go:embed cache.tmpl
+var tmplSrc string
+
+var numericTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true,
+}
+
+var incrementBlock = regexp.MustCompile(`(?s)// gocache-gen:increment-start\n.*?// gocache-gen:increment-end\n\n`)
+
+// generate renders tmplSrc for the given concrete value type and package
+// name, stripping the Increment method unless the value type is numeric.
+func generate(valueType, pkg string) ([]byte, error) {
+	src := tmplSrc
+	if numericTypes[valueType] {
+		src = strings.NewReplacer(
+			"// gocache-gen:increment-start\n", "",
+			"// gocache-gen:increment-end\n", "",
+		).Replace(src)
+	} else {
+		src = incrementBlock.ReplaceAllString(src, "")
+	}
+	src = strings.ReplaceAll(src, "PACKAGE_tpl", pkg)
+	src = strings.ReplaceAll(src, "ValueType_tpl", valueType)
+	src = strings.ReplaceAll(src, "_tpl", "")
+	return format.Source([]byte(src))
+}
+
+func main() {
+	typeName := flag.String("type", "", "concrete value type to specialize the cache for (required)")
+	pkg := flag.String("package", "", "package name for the generated file (required)")
+	output := flag.String("output", "", "output file path (required)")
+	flag.Parse()
+
+	if *typeName == "" || *pkg == "" || *output == "" {
+		fmt.Fprintln(os.Stderr, "gocache-gen: -type, -package and -output are all required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	out, err := generate(*typeName, *pkg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gocache-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*output, out, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "gocache-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
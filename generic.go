@@ -0,0 +1,279 @@
+package goCache
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// TypedItem is the generic item type. Item is an alias for
+// TypedItem[interface{}]. It's named TypedItem rather than Item[V] because
+// a generic type and a plain alias can't share the same name.
+type TypedItem[V any] struct {
+	Object     V
+	Expiration int64
+}
+
+// Expired returns true if the item has expired.
+func (item TypedItem[V]) Expired() bool {
+	if item.Expiration == 0 {
+		return false
+	}
+	return time.Now().UnixNano() > item.Expiration
+}
+
+// TypedCache is a generic cache keyed by K holding values of type V, with
+// the same Set/Get/Add/Replace/Delete/OnEvicted surface as Cache. Cache is
+// implemented by embedding *typedCache[string, interface{}] (see cache in
+// goCache.go) rather than duplicating its Set/Get/Add/Replace/... logic,
+// and only adds its own methods for Item, gob persistence (Save/Load and
+// friends) and Increment and friends, where the concrete interface{} value
+// type needs runtime type-switching that the generic methods don't do.
+// TypedCache is named as it is for the same
+// reason TypedItem is.
+type TypedCache[K comparable, V any] struct {
+	*typedCache[K, V]
+}
+
+type typedCache[K comparable, V any] struct {
+	defaultExpiration time.Duration
+	items             map[K]TypedItem[V]
+	mu                sync.RWMutex
+	onEvicted         func(K, V)
+	janitor           *typedJanitor[K, V]
+}
+
+// Set adds an item to the cache, replacing any existing item.
+func (c *typedCache[K, V]) Set(k K, x V, d time.Duration) {
+	var e int64
+	if d == DefaultExpiration {
+		d = c.defaultExpiration
+	}
+	if d > 0 {
+		e = time.Now().Add(d).UnixNano()
+	}
+	c.mu.Lock()
+	c.items[k] = TypedItem[V]{Object: x, Expiration: e}
+	c.mu.Unlock()
+}
+
+func (c *typedCache[K, V]) set(k K, x V, d time.Duration) {
+	var e int64
+	if d == DefaultExpiration {
+		d = c.defaultExpiration
+	}
+	if d > 0 {
+		e = time.Now().Add(d).UnixNano()
+	}
+	c.items[k] = TypedItem[V]{Object: x, Expiration: e}
+}
+
+func (c *typedCache[K, V]) get(k K) (V, bool) {
+	item, found := c.items[k]
+	if !found || item.Expired() {
+		var zero V
+		return zero, false
+	}
+	return item.Object, true
+}
+
+// Get an item from the cache. Returns the item's zero value and false if
+// the key was not found or the item has expired.
+func (c *typedCache[K, V]) Get(k K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.get(k)
+}
+
+// Add an item to the cache only if an item doesn't already exist for the
+// given key, or if the existing item has expired. Returns an error
+// otherwise.
+func (c *typedCache[K, V]) Add(k K, x V, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.get(k); found {
+		return fmt.Errorf("Item %v already exists", k)
+	}
+	c.set(k, x, d)
+	return nil
+}
+
+// Replace sets a new value for the cache key only if it already exists, and
+// the existing item hasn't expired. Returns an error otherwise.
+func (c *typedCache[K, V]) Replace(k K, x V, d time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, found := c.get(k); !found {
+		return fmt.Errorf("Item %v doesn't exist", k)
+	}
+	c.set(k, x, d)
+	return nil
+}
+
+func (c *typedCache[K, V]) delete(k K) (V, bool) {
+	if c.onEvicted != nil {
+		if v, found := c.items[k]; found {
+			delete(c.items, k)
+			return v.Object, true
+		}
+	}
+	delete(c.items, k)
+	var zero V
+	return zero, false
+}
+
+// Delete an item from the cache. Does nothing if the key is not in the
+// cache.
+func (c *typedCache[K, V]) Delete(k K) {
+	c.mu.Lock()
+	v, evicted := c.delete(k)
+	c.mu.Unlock()
+	if evicted {
+		c.onEvicted(k, v)
+	}
+}
+
+type typedKV[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// DeleteExpired deletes all expired items from the cache.
+func (c *typedCache[K, V]) DeleteExpired() {
+	var evictedItems []typedKV[K, V]
+	now := time.Now().UnixNano()
+	c.mu.Lock()
+	for k, v := range c.items {
+		if v.Expiration > 0 && v.Expiration < now {
+			v, evicted := c.delete(k)
+			if evicted {
+				evictedItems = append(evictedItems, typedKV[K, V]{k, v})
+			}
+		}
+	}
+	c.mu.Unlock()
+	for _, v := range evictedItems {
+		c.onEvicted(v.key, v.value)
+	}
+}
+
+// OnEvicted sets an (optional) function that is called with the key and
+// value when an item is evicted from the cache. Set to nil to disable.
+func (c *typedCache[K, V]) OnEvicted(f func(K, V)) {
+	c.mu.Lock()
+	c.onEvicted = f
+	c.mu.Unlock()
+}
+
+// Items returns a copy of the cache's non-expired items.
+func (c *typedCache[K, V]) Items() map[K]TypedItem[V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make(map[K]TypedItem[V], len(c.items))
+	for k, v := range c.items {
+		if !v.Expired() {
+			items[k] = v
+		}
+	}
+	return items
+}
+
+// ItemCount returns the number of items in the cache. This may include
+// items that have expired but have not yet been cleaned up.
+func (c *typedCache[K, V]) ItemCount() int {
+	c.mu.RLock()
+	n := len(c.items)
+	c.mu.RUnlock()
+	return n
+}
+
+// Flush deletes all items from the cache.
+func (c *typedCache[K, V]) Flush() {
+	c.mu.Lock()
+	c.items = map[K]TypedItem[V]{}
+	c.mu.Unlock()
+}
+
+type typedJanitor[K comparable, V any] struct {
+	Interval time.Duration
+	stop     chan bool
+}
+
+func (j *typedJanitor[K, V]) Run(c *typedCache[K, V]) {
+	j.stop = make(chan bool)
+	ticker := time.NewTicker(j.Interval)
+	for {
+		select {
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-j.stop:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+func stopTypedJanitor[K comparable, V any](c *TypedCache[K, V]) {
+	c.janitor.stop <- true
+}
+
+func runTypedJanitor[K comparable, V any](c *typedCache[K, V], ci time.Duration) {
+	j := &typedJanitor[K, V]{Interval: ci}
+	c.janitor = j
+	go j.Run(c)
+}
+
+// NewTyped returns a new generic cache with the given default expiration
+// duration and cleanup interval. It's named NewTyped rather than New[K, V]
+// because New is already declared as a non-generic function.
+func NewTyped[K comparable, V any](defaultExpiration, cleanupInterval time.Duration) *TypedCache[K, V] {
+	if defaultExpiration == 0 {
+		defaultExpiration = -1
+	}
+	c := &typedCache[K, V]{
+		defaultExpiration: defaultExpiration,
+		items:             make(map[K]TypedItem[V]),
+	}
+	C := &TypedCache[K, V]{c}
+	if cleanupInterval > 0 {
+		runTypedJanitor(c, cleanupInterval)
+		runtime.SetFinalizer(C, stopTypedJanitor[K, V])
+	}
+	return C
+}
+
+// Number is the set of numeric types NumericCache can increment directly,
+// without an interface{} type assertion.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// NumericCache is a TypedCache specialized for a numeric value type, adding
+// an Increment method that performs direct arithmetic instead of the
+// interface{} type switch that Cache.Increment needs.
+type NumericCache[K comparable, N Number] struct {
+	*TypedCache[K, N]
+}
+
+// NewNumeric returns a new NumericCache with the given default expiration
+// duration and cleanup interval.
+func NewNumeric[K comparable, N Number](defaultExpiration, cleanupInterval time.Duration) *NumericCache[K, N] {
+	return &NumericCache[K, N]{NewTyped[K, N](defaultExpiration, cleanupInterval)}
+}
+
+// Increment adds n to the value stored at k. Returns an error if the item
+// was not found or has expired.
+func (c *NumericCache[K, N]) Increment(k K, n N) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, found := c.items[k]
+	if !found || item.Expired() {
+		return fmt.Errorf("Item %v not found or expired", k)
+	}
+	item.Object += n
+	c.items[k] = item
+	return nil
+}
@@ -0,0 +1,220 @@
+package goCache
+
+import "fmt"
+
+// Increment an item of number by n. Returns an error if the item's value
+// is not an integer or float kind, if it was not found, or if it has
+// expired. To retrieve the incremented value without a type assertion, use
+// one of the specialized methods, e.g. IncrementInt64.
+func (c *cache) Increment(k string, n int64) error {
+	c.mu.Lock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		c.mu.Unlock()
+		return fmt.Errorf("Item %s not found or expired", k)
+	}
+	switch v.Object.(type) {
+	case int:
+		v.Object = v.Object.(int) + int(n)
+	case int8:
+		v.Object = v.Object.(int8) + int8(n)
+	case int16:
+		v.Object = v.Object.(int16) + int16(n)
+	case int32:
+		v.Object = v.Object.(int32) + int32(n)
+	case int64:
+		v.Object = v.Object.(int64) + n
+	case uint:
+		v.Object = v.Object.(uint) + uint(n)
+	case uint8:
+		v.Object = v.Object.(uint8) + uint8(n)
+	case uint16:
+		v.Object = v.Object.(uint16) + uint16(n)
+	case uint32:
+		v.Object = v.Object.(uint32) + uint32(n)
+	case uint64:
+		v.Object = v.Object.(uint64) + uint64(n)
+	case uintptr:
+		v.Object = v.Object.(uintptr) + uintptr(n)
+	case float32:
+		v.Object = v.Object.(float32) + float32(n)
+	case float64:
+		v.Object = v.Object.(float64) + float64(n)
+	default:
+		c.mu.Unlock()
+		return fmt.Errorf("Item %s is not a numeric type", k)
+	}
+	c.items[k] = v
+	c.mu.Unlock()
+	return nil
+}
+
+// IncrementFloat increments an item of type float32 or float64 by n.
+// Returns an error if the item's value is not one of these, if it was not
+// found, or if it has expired.
+func (c *cache) IncrementFloat(k string, n float64) error {
+	c.mu.Lock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		c.mu.Unlock()
+		return fmt.Errorf("Item %s not found or expired", k)
+	}
+	switch v.Object.(type) {
+	case float32:
+		v.Object = v.Object.(float32) + float32(n)
+	case float64:
+		v.Object = v.Object.(float64) + n
+	default:
+		c.mu.Unlock()
+		return fmt.Errorf("Item %s is not a float type", k)
+	}
+	c.items[k] = v
+	c.mu.Unlock()
+	return nil
+}
+
+// Decrement an item of number by n. Returns an error if the item's value
+// is not an integer or float kind, if it was not found, or if it has
+// expired. To retrieve the decremented value without a type assertion, use
+// one of the specialized methods, e.g. DecrementInt64.
+func (c *cache) Decrement(k string, n int64) error {
+	return c.Increment(k, -n)
+}
+
+// incrementInt increments an item stored as int and returns the new value.
+func (c *cache) incrementInt(k string, n int) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		return 0, fmt.Errorf("Item %s not found or expired", k)
+	}
+	rv, ok := v.Object.(int)
+	if !ok {
+		return 0, fmt.Errorf("Item %s is not an int", k)
+	}
+	rv += n
+	v.Object = rv
+	c.items[k] = v
+	return rv, nil
+}
+
+// IncrementInt increments an item of type int by n and returns the new
+// value. Returns an error if the item's value is not an int, if it was not
+// found, or if it has expired.
+func (c *cache) IncrementInt(k string, n int) (int, error) {
+	return c.incrementInt(k, n)
+}
+
+// DecrementInt decrements an item of type int by n and returns the new
+// value.
+func (c *cache) DecrementInt(k string, n int) (int, error) {
+	return c.incrementInt(k, -n)
+}
+
+func (c *cache) incrementInt64(k string, n int64) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		return 0, fmt.Errorf("Item %s not found or expired", k)
+	}
+	rv, ok := v.Object.(int64)
+	if !ok {
+		return 0, fmt.Errorf("Item %s is not an int64", k)
+	}
+	rv += n
+	v.Object = rv
+	c.items[k] = v
+	return rv, nil
+}
+
+// IncrementInt64 increments an item of type int64 by n and returns the new
+// value. Returns an error if the item's value is not an int64, if it was
+// not found, or if it has expired.
+func (c *cache) IncrementInt64(k string, n int64) (int64, error) {
+	return c.incrementInt64(k, n)
+}
+
+// DecrementInt64 decrements an item of type int64 by n and returns the new
+// value.
+func (c *cache) DecrementInt64(k string, n int64) (int64, error) {
+	return c.incrementInt64(k, -n)
+}
+
+func (c *cache) incrementUint64(k string, n uint64) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		return 0, fmt.Errorf("Item %s not found or expired", k)
+	}
+	rv, ok := v.Object.(uint64)
+	if !ok {
+		return 0, fmt.Errorf("Item %s is not a uint64", k)
+	}
+	rv += n
+	v.Object = rv
+	c.items[k] = v
+	return rv, nil
+}
+
+// IncrementUint64 increments an item of type uint64 by n and returns the
+// new value. Returns an error if the item's value is not a uint64, if it
+// was not found, or if it has expired.
+func (c *cache) IncrementUint64(k string, n uint64) (uint64, error) {
+	return c.incrementUint64(k, n)
+}
+
+// DecrementUint64 decrements an item of type uint64 by n and returns the
+// new value. Returns an error if n is greater than the current value,
+// since uint64 can't represent a negative result.
+func (c *cache) DecrementUint64(k string, n uint64) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		return 0, fmt.Errorf("Item %s not found or expired", k)
+	}
+	rv, ok := v.Object.(uint64)
+	if !ok {
+		return 0, fmt.Errorf("Item %s is not a uint64", k)
+	}
+	if n > rv {
+		return 0, fmt.Errorf("Item %s: cannot decrement %d by %d below zero", k, rv, n)
+	}
+	rv -= n
+	v.Object = rv
+	c.items[k] = v
+	return rv, nil
+}
+
+func (c *cache) incrementFloat64(k string, n float64) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, found := c.items[k]
+	if !found || v.Expired() {
+		return 0, fmt.Errorf("Item %s not found or expired", k)
+	}
+	rv, ok := v.Object.(float64)
+	if !ok {
+		return 0, fmt.Errorf("Item %s is not a float64", k)
+	}
+	rv += n
+	v.Object = rv
+	c.items[k] = v
+	return rv, nil
+}
+
+// IncrementFloat64 increments an item of type float64 by n and returns the
+// new value. Returns an error if the item's value is not a float64, if it
+// was not found, or if it has expired.
+func (c *cache) IncrementFloat64(k string, n float64) (float64, error) {
+	return c.incrementFloat64(k, n)
+}
+
+// DecrementFloat64 decrements an item of type float64 by n and returns the
+// new value.
+func (c *cache) DecrementFloat64(k string, n float64) (float64, error) {
+	return c.incrementFloat64(k, -n)
+}
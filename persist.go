@@ -0,0 +1,86 @@
+package goCache
+
+import (
+	"encoding/gob"
+	"io"
+	"os"
+	"time"
+)
+
+func init() {
+	// Register common concrete types so gob can encode/decode Items whose
+	// Object holds one of these without the caller having to do it. If you
+	// store your own concrete types in Object, call gob.Register(YourType{})
+	// yourself before calling Save/Load.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// Save writes the cache's items to w as a gob stream. It does not save the
+// default expiration or cleanup interval; use NewFrom or Load to restore
+// into a cache that's already configured with those.
+func (c *cache) Save(w io.Writer) (err error) {
+	enc := gob.NewEncoder(w)
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return enc.Encode(&c.items)
+}
+
+// SaveFile saves the cache's items to the given filename, creating it if it
+// doesn't exist and overwriting it if it does.
+func (c *cache) SaveFile(fname string) error {
+	f, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	err = c.Save(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Load adds the gob-serialized items from r to the cache. Existing, unexpired
+// items are not overwritten: this is meant to be used as a "warm the cache
+// from a snapshot at startup" primitive for a cache that may already have
+// been populated with fresher data.
+func (c *cache) Load(r io.Reader) error {
+	items := map[string]Item{}
+	dec := gob.NewDecoder(r)
+	if err := dec.Decode(&items); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, v := range items {
+		ov, found := c.items[k]
+		if !found || ov.Expired() {
+			c.items[k] = v
+		}
+	}
+	return nil
+}
+
+// LoadFile adds the gob-serialized items in the given file to the cache.
+func (c *cache) LoadFile(fname string) error {
+	f, err := os.Open(fname)
+	if err != nil {
+		return err
+	}
+	err = c.Load(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// NewFrom returns a new cache seeded with the given items, which is useful
+// if you want to load a cache from a snapshot taken with Save/SaveFile
+// before adding a janitor to it.
+func NewFrom(defaultExpiration, cleanupInterval time.Duration, items map[string]Item) *Cache {
+	return NewWithOptions(Options{
+		DefaultExpiration: defaultExpiration,
+		CleanupInterval:   cleanupInterval,
+		Items:             items,
+	})
+}
@@ -0,0 +1,126 @@
+package goCache
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+)
+
+const (
+	fnvOffsetBasis32 uint32 = 2166136261
+	fnvPrime32       uint32 = 16777619
+)
+
+// ShardedCache partitions keys across a fixed number of independent cache
+// shards, each guarded by its own mutex and janitor. This trades the single
+// global lock of Cache for lower contention under concurrent Get/Set from
+// many goroutines.
+type ShardedCache struct {
+	seed   uint32
+	shards []*Cache
+}
+
+// hash returns an FNV-1a hash of k mixed with the cache's random seed, so
+// that key-to-shard distribution can't be guessed or targeted by an
+// adversary who knows the hash function.
+func (sc *ShardedCache) hash(k string) uint32 {
+	h := fnvOffsetBasis32 ^ sc.seed
+	for i := 0; i < len(k); i++ {
+		h ^= uint32(k[i])
+		h *= fnvPrime32
+	}
+	return h
+}
+
+func (sc *ShardedCache) shard(k string) *Cache {
+	return sc.shards[sc.hash(k)%uint32(len(sc.shards))]
+}
+
+// randomSeed32 returns a random 32-bit seed, falling back to the FNV offset
+// basis if the system random source can't be read.
+func randomSeed32() uint32 {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fnvOffsetBasis32
+	}
+	return binary.LittleEndian.Uint32(b[:])
+}
+
+// NewSharded returns a cache partitioned into the given number of shards,
+// each with its own janitor. shards <= 0 is treated as 1.
+func NewSharded(defaultExpiration, cleanupInterval time.Duration, shards int) *ShardedCache {
+	if shards <= 0 {
+		shards = 1
+	}
+	sc := &ShardedCache{
+		seed:   randomSeed32(),
+		shards: make([]*Cache, shards),
+	}
+	for i := range sc.shards {
+		sc.shards[i] = New(defaultExpiration, cleanupInterval)
+	}
+	return sc
+}
+
+// Set adds an item to the cache, replacing any existing item.
+func (sc *ShardedCache) Set(k string, x interface{}, d time.Duration) {
+	sc.shard(k).Set(k, x, d)
+}
+
+// Get an item from the cache. Returns the item or nil, and a bool indicating
+// whether the key was found.
+func (sc *ShardedCache) Get(k string) (interface{}, bool) {
+	return sc.shard(k).Get(k)
+}
+
+// Add an item to the cache only if an item doesn't already exist for the
+// given key, or if the existing item has expired. Returns an error
+// otherwise.
+func (sc *ShardedCache) Add(k string, x interface{}, d time.Duration) error {
+	return sc.shard(k).Add(k, x, d)
+}
+
+// Replace sets a new value for the cache key only if it already exists, and
+// the existing item hasn't expired. Returns an error otherwise.
+func (sc *ShardedCache) Replace(k string, x interface{}, d time.Duration) error {
+	return sc.shard(k).Replace(k, x, d)
+}
+
+// Delete an item from the cache. Does nothing if the key is not in the
+// cache.
+func (sc *ShardedCache) Delete(k string) {
+	sc.shard(k).Delete(k)
+}
+
+// Increment an item of number by n. Returns an error if the item's value
+// is not an integer or float kind, if it was not found, or if it has
+// expired.
+func (sc *ShardedCache) Increment(k string, n int64) error {
+	return sc.shard(k).Increment(k, n)
+}
+
+// ItemCount returns the total number of items across all shards. This
+// includes items that have expired but have not yet been cleaned up.
+func (sc *ShardedCache) ItemCount() int {
+	n := 0
+	for _, c := range sc.shards {
+		n += c.ItemCount()
+	}
+	return n
+}
+
+// Flush deletes all items from every shard.
+func (sc *ShardedCache) Flush() {
+	for _, c := range sc.shards {
+		c.Flush()
+	}
+}
+
+// DeleteExpired deletes expired items from every shard, one shard at a
+// time, so that sweeping the whole cache doesn't hold any single shard's
+// lock for longer than it takes to sweep that shard alone.
+func (sc *ShardedCache) DeleteExpired() {
+	for _, c := range sc.shards {
+		c.DeleteExpired()
+	}
+}
@@ -0,0 +1,68 @@
+package goCache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// benchKeys are pre-generated so key formatting doesn't skew the benchmark.
+var benchKeys = func() []string {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+	return keys
+}()
+
+func BenchmarkCacheGetSetParallel(b *testing.B) {
+	c := New(DefaultExpiration, 0)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := benchKeys[i%len(benchKeys)]
+			c.Set(k, i, DefaultExpiration)
+			c.Get(k)
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedCacheGetSetParallel(b *testing.B) {
+	sc := NewSharded(DefaultExpiration, 0, 32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := benchKeys[i%len(benchKeys)]
+			sc.Set(k, i, DefaultExpiration)
+			sc.Get(k)
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheGetSetParallelExpiring(b *testing.B) {
+	c := New(time.Minute, 0)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := benchKeys[i%len(benchKeys)]
+			c.Set(k, i, time.Minute)
+			c.Get(k)
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedCacheGetSetParallelExpiring(b *testing.B) {
+	sc := NewSharded(time.Minute, 0, 32)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := benchKeys[i%len(benchKeys)]
+			sc.Set(k, i, time.Minute)
+			sc.Get(k)
+			i++
+		}
+	})
+}